@@ -72,6 +72,13 @@ uint Fingerprint::adaptiveOnsets(int ttarg, matrix_u&out, uint*&onset_counter_fo
         ham[i] = .5 - .5*cos( (2.*M_PI/(nsm-1))*i);
 
     int nc =  floor((float)E.size2()/(float)hop)-(floor((float)nsm/(float)hop)-1);
+    if (nc <= 0) {
+        // not enough subband frames for a single smoothing window
+        out = matrix_u(SUBBANDS, 0);
+        onset_counter_for_band = new uint[SUBBANDS];
+        for (j = 0; j < SUBBANDS; ++j) onset_counter_for_band[j] = 0;
+        return 0;
+    }
     matrix_f Eb = matrix_f(nc, 8);
     for(uint r=0;r<Eb.size1();r++) for(uint c=0;c<Eb.size2();c++) Eb(r,c) = 0.0;
 
@@ -43,8 +43,15 @@ void SubbandAnalysis::Compute() {
     float Z[C_LEN];
     float Y[M_COLS];
 
+    // Too short for a single frame: leave an empty matrix rather than
+    // letting the unsigned subtraction wrap around.
+    if (_NumSamples < C_LEN) {
+        _NumFrames = 0;
+        _Data = matrix_f(SUBBANDS, 0);
+        return;
+    }
+
     _NumFrames = (_NumSamples - C_LEN + 1)/SUBBANDS;
-    assert(_NumFrames > 0);
 
     _Data = matrix_f(SUBBANDS, _NumFrames);
 
@@ -34,7 +34,7 @@ void Whitening::Init() {
     _R[0] = 0.001;
 
     _Xo = (float *)malloc((_p+1)*sizeof(float));
-    for (i = 0; i < _p; ++i)  { _Xo[i] = 0.0; }
+    for (i = 0; i <= _p; ++i) { _Xo[i] = 0.0; }
 
     _ai = (float *)malloc((_p+1)*sizeof(float));
     _whitened = (float*) malloc(sizeof(float)*_NumSamples);
@@ -57,6 +57,10 @@ void Whitening::ComputeBlock(int start, int blockSize) {
     float T = 8;
     alpha = 1.0/T;
 
+    if (blockSize <= 0) {
+        return;
+    }
+
     // calculate autocorrelation of current block
 
     for (i = 0; i <= _p; ++i) {
@@ -72,6 +76,15 @@ void Whitening::ComputeBlock(int start, int blockSize) {
     // Durbin's recursion, per p. 411 of Rabiner & Schafer 1978
     E = _R[0];
     for (i = 1; i <= _p; ++i) {
+        if (E <= FLT_MIN) {
+            // the prediction error has vanished (silent or perfectly
+            // predictable block): keep the lower order predictor rather
+            // than dividing by zero and spreading NaNs into the output
+            for (j = i; j <= _p; ++j) {
+                _ai[j] = 0;
+            }
+            break;
+        }
         float sumalphaR = 0;
         for (j = 1; j < i; ++j) {
             sumalphaR += _ai[j]*_R[i-j];
@@ -102,9 +115,15 @@ void Whitening::ComputeBlock(int start, int blockSize) {
         }
         _whitened[i+start] = acc;
     }
-    // save last few frames of input
+    // save last few frames of input; a block shorter than the filter
+    // order keeps the tail of the previous history instead of reading
+    // before the start of the buffer
     for (i = 0; i <= _p; ++i) {
-        _Xo[i] = _pSamples[blockSize-1-_p+i+start];
+        if (i + blockSize <= _p) {
+            _Xo[i] = _Xo[i+blockSize];
+        } else {
+            _Xo[i] = _pSamples[blockSize-1-_p+i+start];
+        }
     }
 }
 